@@ -0,0 +1,168 @@
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// GenerationMode controls how generateTemplate reconciles a freshly rendered template
+// against an existing output file.
+type GenerationMode int
+
+const (
+	// ModeOverwrite writes the rendered template, skipping the write entirely when the
+	// rendered content is unchanged from what is already on disk. This is the default.
+	ModeOverwrite GenerationMode = iota
+	// ModeMerge behaves like ModeOverwrite but additionally preserves the contents of any
+	// "BEGIN USER"/"END USER" region already present in the existing output file.
+	ModeMerge
+	// ModeDryRun never writes; it prints a diff of what would change to stdout.
+	ModeDryRun
+)
+
+const (
+	generationMode  = "generationMode"
+	managedHashTag  = "managed-hash"
+	userRegionBegin = "BEGIN USER"
+	userRegionEnd   = "END USER"
+)
+
+// WithGenerationMode controls whether Generate overwrites output files outright (the
+// default, ModeOverwrite), merges them to preserve BEGIN USER/END USER regions (ModeMerge),
+// or only reports what would change without writing anything (ModeDryRun).
+func (a App) WithGenerationMode(mode GenerationMode) setupOp {
+	a.settings[generationMode] = mode
+	return noOp("WithGenerationMode")
+}
+
+// mode returns the configured GenerationMode, defaulting to ModeOverwrite.
+func (a App) mode() GenerationMode {
+	if m, ok := a.settings[generationMode]; ok {
+		return m.(GenerationMode)
+	}
+	return ModeOverwrite
+}
+
+// sha256Hex returns the hex-encoded SHA-256 of body.
+func sha256Hex(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// hashLine returns the "<comment-prefix>managed-hash: <hex>" line embedded in generated
+// files so later runs can tell whether the body (not counting the warning/hash lines
+// themselves) has changed.
+func hashLine(fileType string, body []byte) string {
+	return commentPrefix(fileType) + managedHashTag + ": " + sha256Hex(body) + "\n"
+}
+
+// commentPrefix returns the line-comment prefix used for fileType, matching the one already
+// used for the "DO NOT EDIT" warning.
+func commentPrefix(fileType string) string {
+	if strings.HasPrefix(warnings[fileType], "# ") {
+		return "# "
+	}
+	return "// "
+}
+
+// existingHash extracts the managed-hash value embedded in an already-generated file, if any.
+func existingHash(content []byte) (string, bool) {
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if idx := strings.Index(line, managedHashTag+": "); idx >= 0 {
+			return strings.TrimSpace(line[idx+len(managedHashTag)+2:]), true
+		}
+	}
+	return "", false
+}
+
+// userRegions extracts, in order, the contents of every BEGIN USER/END USER block in content.
+func userRegions(content []byte) []string {
+	var regions []string
+	var current []string
+	inRegion := false
+
+	for _, line := range strings.Split(string(content), "\n") {
+		switch {
+		case strings.Contains(line, userRegionBegin):
+			inRegion = true
+			current = nil
+		case strings.Contains(line, userRegionEnd):
+			if inRegion {
+				regions = append(regions, strings.Join(current, "\n"))
+			}
+			inRegion = false
+		case inRegion:
+			current = append(current, line)
+		}
+	}
+	return regions
+}
+
+// mergeUserRegions replaces the Nth BEGIN USER/END USER block in body with the Nth entry of
+// regions, leaving the template's own content for any block beyond len(regions).
+func mergeUserRegions(body []byte, regions []string) []byte {
+	if len(regions) == 0 {
+		return body
+	}
+
+	var out []string
+	var current []string
+	inRegion := false
+	region := 0
+
+	for _, line := range strings.Split(string(body), "\n") {
+		switch {
+		case strings.Contains(line, userRegionBegin):
+			out = append(out, line)
+			inRegion = true
+			current = nil
+		case strings.Contains(line, userRegionEnd):
+			if inRegion {
+				if region < len(regions) {
+					out = append(out, strings.Split(regions[region], "\n")...)
+				} else {
+					out = append(out, current...)
+				}
+				region++
+			}
+			inRegion = false
+			out = append(out, line)
+		case inRegion:
+			current = append(current, line)
+		default:
+			out = append(out, line)
+		}
+	}
+	return []byte(strings.Join(out, "\n"))
+}
+
+// unifiedDiff renders a simple line-based diff of old -> new for ModeDryRun. It is not a
+// minimal LCS diff: it walks both sides in order, keeps lines that match, marks old lines as
+// removed until one matches the next new line, and marks the remaining new lines as added.
+// That is enough to show a human what a regeneration would change.
+func unifiedDiff(path string, oldContent, newContent []byte) string {
+	oldLines := strings.Split(string(oldContent), "\n")
+	newLines := strings.Split(string(newContent), "\n")
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "--- %s\n+++ %s\n", path, path)
+	for i, j := 0, 0; i < len(oldLines) || j < len(newLines); {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			fmt.Fprintf(&buf, "  %s\n", oldLines[i])
+			i++
+			j++
+		case i < len(oldLines) && (j >= len(newLines) || oldLines[i] != newLines[j]):
+			fmt.Fprintf(&buf, "- %s\n", oldLines[i])
+			i++
+		default:
+			fmt.Fprintf(&buf, "+ %s\n", newLines[j])
+			j++
+		}
+	}
+	return buf.String()
+}
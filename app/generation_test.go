@@ -0,0 +1,133 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSha256Hex(t *testing.T) {
+	a := sha256Hex([]byte("hello"))
+	b := sha256Hex([]byte("hello"))
+	c := sha256Hex([]byte("world"))
+
+	if a != b {
+		t.Errorf("sha256Hex is not deterministic: %q != %q", a, b)
+	}
+	if a == c {
+		t.Errorf("sha256Hex should differ for different input, got %q for both", a)
+	}
+}
+
+func TestExistingHash(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantOk  bool
+	}{
+		{
+			name:    "present",
+			content: "// warning\n// managed-hash: abc123\npackage main\n",
+			want:    "abc123",
+			wantOk:  true,
+		},
+		{
+			name:    "hash comment style",
+			content: "# warning\n# managed-hash: def456\nALL: build\n",
+			want:    "def456",
+			wantOk:  true,
+		},
+		{
+			name:    "absent",
+			content: "package main\n",
+			want:    "",
+			wantOk:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := existingHash([]byte(tt.content))
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("existingHash(%q) = (%q, %v), want (%q, %v)", tt.content, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestUserRegions(t *testing.T) {
+	content := "before\n" +
+		"// BEGIN USER\n" +
+		"custom one\n" +
+		"// END USER\n" +
+		"middle\n" +
+		"// BEGIN USER\n" +
+		"custom two\n" +
+		"line two\n" +
+		"// END USER\n" +
+		"after\n"
+
+	got := userRegions([]byte(content))
+	want := []string{"custom one", "custom two\nline two"}
+
+	if len(got) != len(want) {
+		t.Fatalf("userRegions() returned %d regions, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("userRegions()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestUserRegionsUnbalanced(t *testing.T) {
+	content := "// BEGIN USER\nunterminated\n"
+
+	got := userRegions([]byte(content))
+	if len(got) != 0 {
+		t.Errorf("userRegions() with no END USER = %v, want no regions", got)
+	}
+}
+
+func TestMergeUserRegions(t *testing.T) {
+	body := "before\n" +
+		"// BEGIN USER\n" +
+		"template default\n" +
+		"// END USER\n" +
+		"after\n"
+
+	t.Run("replaces region with preserved content", func(t *testing.T) {
+		got := string(mergeUserRegions([]byte(body), []string{"user edit"}))
+		want := "before\n// BEGIN USER\nuser edit\n// END USER\nafter\n"
+		if got != want {
+			t.Errorf("mergeUserRegions() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("leaves template default when no region is preserved", func(t *testing.T) {
+		got := string(mergeUserRegions([]byte(body), nil))
+		if got != body {
+			t.Errorf("mergeUserRegions() with no regions = %q, want unchanged %q", got, body)
+		}
+	})
+
+	t.Run("leaves extra template regions untouched beyond len(regions)", func(t *testing.T) {
+		twoRegions := body + "// BEGIN USER\nsecond default\n// END USER\n"
+		got := string(mergeUserRegions([]byte(twoRegions), []string{"user edit"}))
+		want := "before\n// BEGIN USER\nuser edit\n// END USER\nafter\n// BEGIN USER\nsecond default\n// END USER\n"
+		if got != want {
+			t.Errorf("mergeUserRegions() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	diff := unifiedDiff("output.txt", []byte("a\nb\nc\n"), []byte("a\nx\nc\n"))
+
+	if want := "--- output.txt\n+++ output.txt\n"; len(diff) < len(want) || diff[:len(want)] != want {
+		t.Errorf("unifiedDiff() header = %q, want prefix %q", diff, want)
+	}
+	if !strings.Contains(diff, "- b") || !strings.Contains(diff, "+ x") {
+		t.Errorf("unifiedDiff() = %q, want it to show the changed line", diff)
+	}
+}
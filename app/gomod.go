@@ -0,0 +1,56 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// defaultDependencyVersion is used for WithDependencies entries that do not specify one.
+const defaultDependencyVersion = "v0.0.0"
+
+// dependencyVersionSep separates a dependency's module path from an optional version, e.g.
+// "github.com/skeletonkey/lib-core-go@v1.2.3".
+const dependencyVersionSep = "@"
+
+// updateGoMod parses the existing go.mod (if present) and applies mutate, writing the
+// result back in canonical formatting. It is a no-op if go.mod does not exist.
+func updateGoMod(mutate func(*modfile.File) error) error {
+	data, err := os.ReadFile(goModFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("unable to read go.mod file (%s): %s", goModFile, err)
+	}
+
+	f, err := modfile.Parse(goModFile, data, nil)
+	if err != nil {
+		return fmt.Errorf("unable to parse go.mod file (%s): %s", goModFile, err)
+	}
+
+	if err := mutate(f); err != nil {
+		return err
+	}
+
+	f.Cleanup()
+	newData, err := f.Format()
+	if err != nil {
+		return fmt.Errorf("unable to format go.mod file (%s): %s", goModFile, err)
+	}
+
+	if err := os.WriteFile(goModFile, newData, newFilePermission); err != nil {
+		return fmt.Errorf("unable to write go.mod file (%s): %s", goModFile, err)
+	}
+	return nil
+}
+
+// splitDependency pulls the optional "@version" suffix off of a WithDependencies entry.
+func splitDependency(dep string) (modPath string, version string) {
+	if i := strings.LastIndex(dep, dependencyVersionSep); i >= 0 {
+		return dep[:i], dep[i+1:]
+	}
+	return dep, defaultDependencyVersion
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"os"
+	"testing"
+
+	"golang.org/x/mod/modfile"
+)
+
+func TestSplitDependency(t *testing.T) {
+	tests := []struct {
+		name        string
+		dep         string
+		wantPath    string
+		wantVersion string
+	}{
+		{name: "no version", dep: "github.com/skeletonkey/lib-core-go", wantPath: "github.com/skeletonkey/lib-core-go", wantVersion: defaultDependencyVersion},
+		{name: "pinned version", dep: "github.com/skeletonkey/lib-core-go@v1.2.3", wantPath: "github.com/skeletonkey/lib-core-go", wantVersion: "v1.2.3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPath, gotVersion := splitDependency(tt.dep)
+			if gotPath != tt.wantPath || gotVersion != tt.wantVersion {
+				t.Errorf("splitDependency(%q) = (%q, %q), want (%q, %q)", tt.dep, gotPath, gotVersion, tt.wantPath, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestUpdateGoMod(t *testing.T) {
+	t.Run("no-op when go.mod is absent", func(t *testing.T) {
+		chdir(t, t.TempDir())
+
+		called := false
+		if err := updateGoMod(func(*modfile.File) error {
+			called = true
+			return nil
+		}); err != nil {
+			t.Fatalf("updateGoMod returned error: %s", err)
+		}
+		if called {
+			t.Error("mutate should not be called when go.mod does not exist")
+		}
+	})
+
+	t.Run("applies mutate and reformats go.mod", func(t *testing.T) {
+		dir := t.TempDir()
+		chdir(t, dir)
+
+		original := "module example.com/thing\n\ngo 1.20\n"
+		if err := os.WriteFile(goModFile, []byte(original), newFilePermission); err != nil {
+			t.Fatalf("unable to seed go.mod: %s", err)
+		}
+
+		err := updateGoMod(func(f *modfile.File) error {
+			return f.AddGoStmt("1.23")
+		})
+		if err != nil {
+			t.Fatalf("updateGoMod returned error: %s", err)
+		}
+
+		data, err := os.ReadFile(goModFile)
+		if err != nil {
+			t.Fatalf("unable to read go.mod: %s", err)
+		}
+
+		f, err := modfile.Parse(goModFile, data, nil)
+		if err != nil {
+			t.Fatalf("unable to parse rewritten go.mod: %s", err)
+		}
+		if f.Go == nil || f.Go.Version != "1.23" {
+			t.Errorf("go.mod go version = %v, want 1.23", f.Go)
+		}
+	})
+}
+
+// chdir switches to dir for the duration of the test, restoring the original working
+// directory on cleanup.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("unable to get working directory: %s", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("unable to chdir to %s: %s", dir, err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(orig)
+	})
+}
@@ -5,7 +5,7 @@
 // Reference README.md for examples.
 //
 //	app := instanceGen.NewApp("rachio-next-run", "app")
-//	app.SetupApp(
+//	err := app.SetupApp(
 //		app.WithGithubWorkflows("linter", "test"),
 //		app.WithGoVersion("1.23"),
 //		app.WithMakefile(),
@@ -16,13 +16,17 @@
 package app
 
 import (
+	"bytes"
 	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path"
-	"regexp"
 	"strings"
 	"text/template"
+
+	"golang.org/x/mod/modfile"
 )
 
 //go:embed all:templates
@@ -34,14 +38,22 @@ const (
 	dependencies       = "dependencies"
 	goModFile          = "go.mod"
 	goVersion          = "GoVersion"
+	hookSetting        = "hook"
 	mkfilesSubDir      = "Makefile"
 	newDirPermission   = 0750
 	newFilePermission  = 0644
 	templateBaseDir    = "templates"
+	templateDir        = "templateDir"
+	templateOverlay    = "templateOverlay"
 	warning            = "lib-instance-gen-go: File auto generated -- DO NOT EDIT!!!\n"
 )
 
-type setupOp func(App) error
+// setupOp is a single With* call: name identifies it in Generate's aggregated error and in
+// Event.OpName, run performs the work.
+type setupOp struct {
+	name string
+	run  func(App) error
+}
 
 var templateExts = map[string]string{
 	"go":     ".go.tpl",
@@ -51,6 +63,7 @@ var templateExts = map[string]string{
 }
 var warnings = map[string]string{
 	codeOwnersFileName: "# " + warning,
+	"custom":           "# " + warning,
 	"go":               "// " + warning,
 	"mkfile":           "// " + warning,
 	"toml":             "# " + warning,
@@ -65,8 +78,15 @@ type App struct {
 	settings   map[string]any // misc settings
 }
 
-func noOp() setupOp {
-	return func(_ App) error { return nil }
+// newOp wraps run as a named setupOp.
+func newOp(name string, run func(App) error) setupOp {
+	return setupOp{name: name, run: run}
+}
+
+// noOp is a named setupOp that does nothing, for With* calls that only record a setting for
+// a later op to read (e.g. WithCGOEnabled).
+func noOp(name string) setupOp {
+	return newOp(name, func(_ App) error { return nil })
 }
 
 // NewApp returns the struct for a new applications which allows for generating boilerplate files.
@@ -82,14 +102,63 @@ func (a App) SetupApp(ops ...setupOp) App {
 	return a
 }
 
-// Generate will apply all the settings and create the boilerplate files.
-func (a App) Generate() {
+// Generate applies every With* op and creates the boilerplate files, returning every
+// failure (not just the first) joined together with errors.Join, each wrapped with the
+// name of the op that produced it.
+func (a App) Generate() error {
+	var errs []error
 	for _, op := range a.ops {
-		err := op(a)
-		if err != nil {
-			panic(err)
+		if err := op.run(a); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", op.name, err))
 		}
 	}
+	return errors.Join(errs...)
+}
+
+// MustGenerate is Generate, but panics on error. It exists for callers (and existing
+// init.go files) that prefer the old panic-on-error ergonomics over checking an error.
+func (a App) MustGenerate() {
+	if err := a.Generate(); err != nil {
+		panic(err)
+	}
+}
+
+// WithHook registers hook to be called once per generated file with an Event describing
+// what happened, so callers can log, report to CI, or build a summary table.
+func (a App) WithHook(hook func(Event)) setupOp {
+	a.settings[hookSetting] = hook
+	return noOp("WithHook")
+}
+
+// emit calls the hook registered via WithHook, if any.
+func (a App) emit(ev Event) {
+	if h, ok := a.settings[hookSetting]; ok {
+		if hook := h.(func(Event)); hook != nil {
+			hook(ev)
+		}
+	}
+}
+
+// Action describes what generateTemplate did with a single output file.
+type Action int
+
+const (
+	// ActionWrote means the rendered template was written to disk.
+	ActionWrote Action = iota
+	// ActionSkipped means the output already matched the rendered template (or the run
+	// was a ModeDryRun preview), so nothing was written.
+	ActionSkipped
+	// ActionFailed means rendering or writing the output failed; see Event.Err.
+	ActionFailed
+)
+
+// Event is reported to a WithHook hook once per generated file.
+type Event struct {
+	OpName       string // name of the With* op that triggered this generation
+	OutputPath   string // path the file was (or would have been) written to
+	TemplateName string // name of the template that was rendered
+	Action       Action
+	Err          error // set when Action is ActionFailed
 }
 
 // WithCodeOwners creates a CODEOWNERS file with the provided codeowners configuration.
@@ -97,70 +166,68 @@ func (a App) Generate() {
 // Each string provided will be written on a single line, which provides flexibility.
 // However, once the file becomes complicated, it will be best to create the file manually.
 func (App) WithCodeOwners(codeOwners ...string) setupOp {
+	const opName = "WithCodeOwners"
 	if len(codeOwners) == 0 {
-		return noOp()
+		return noOp(opName)
 	}
 
-	return func(_ App) error {
-		file, err := os.OpenFile(codeOwnersFileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, newFilePermission)
-		if err != nil {
-			return fmt.Errorf("unable to write file (%s): %s", codeOwnersFileName, err)
-		}
-		defer func() {
-			_ = file.Close()
-		}()
-
-		warning, found := warnings[codeOwnersFileName]
-		if !found {
-			return fmt.Errorf("unable to find a 'warnings' entry for %s", codeOwnersFileName)
-		}
-		if _, err := file.WriteString(warning); err != nil {
-			return fmt.Errorf("unable to write warning to file (%s): %s", codeOwnersFileName, err)
-		}
-		if _, err := file.WriteString(strings.Join(codeOwners, "\n")); err != nil {
-			return fmt.Errorf("unable to write codeOwners (%s) to file (%s): %s", codeOwners, codeOwnersFileName, err)
-		}
-
-		return nil
-	}
+	return newOp(opName, func(a App) error {
+		return generateTemplate(generateTemplateArgs{
+			opName:       opName,
+			app:          a,
+			fileType:     codeOwnersFileName,
+			outputName:   codeOwnersFileName,
+			outputSubDir: "",
+			templateName: "codeowners.tpl",
+			templateArgs: templateArgs{CodeOwners: strings.Join(codeOwners, "\n")},
+		})
+	})
 }
 
 // WithPackages takes a list of strings which results in creating a skeleton subdirectory for each.
 // Foreach package listed the following will be created:
 //   - config.go - template to use github.com/skeletonkey/lib-core-go/config module
 func (App) WithPackages(packageNames ...string) setupOp {
-	return func(a App) error {
+	const opName = "WithPackages"
+	return newOp(opName, func(a App) error {
 		for _, name := range packageNames {
 			packageName := name
 			templateArgs := templateArgs{
 				PackageName: packageName,
 			}
-			generateTemplate(generateTemplateArgs{
+			if err := generateTemplate(generateTemplateArgs{
+				opName:         opName,
+				app:            a,
 				fileType:       "go",
 				outputName:     "config.go",
 				outputSubDir:   path.Join(a.dir, name),
 				templateName:   "config" + templateExts["go"],
 				templateSubDir: "package",
 				templateArgs:   templateArgs,
-			})
+			}); err != nil {
+				return err
+			}
 		}
 		return nil
-	}
+	})
 }
 
 // WithCGOEnabled will add CGO_ENABLED=1 to the build statement
 func (a App) WithCGOEnabled() setupOp {
 	a.settings[cgoEnabled] = true
-	return noOp()
+	return noOp("WithCGOEnabled")
 }
 
 // WithConfig adds a config file for the main app. Config
 func (App) WithConfig() setupOp {
-	return func(a App) error {
+	const opName = "WithConfig"
+	return newOp(opName, func(a App) error {
 		templateArgs := templateArgs{
 			ConfigName: a.dir,
 		}
-		generateTemplate(generateTemplateArgs{
+		return generateTemplate(generateTemplateArgs{
+			opName:         opName,
+			app:            a,
 			fileType:       "go",
 			outputName:     "config.go",
 			outputSubDir:   a.dir,
@@ -168,14 +235,95 @@ func (App) WithConfig() setupOp {
 			templateSubDir: "",
 			templateArgs:   templateArgs,
 		})
-		return nil
-	}
+	})
 }
 
-// WithDependencies received a list of strings that are Go libraries that should only be updated with 'make golib-latest'
+// WithDependencies received a list of strings that are Go libraries that should only be updated with 'make golib-latest'.
+// Each entry is also added as a require directive in go.mod (if present); an entry may pin a
+// version with "module@version", otherwise defaultDependencyVersion is used as a placeholder
+// until 'make golib-latest' resolves the real one.
 func (a App) WithDependencies(deps ...string) setupOp {
 	a.settings[dependencies] = deps
-	return noOp()
+
+	return newOp("WithDependencies", func(_ App) error {
+		return updateGoMod(func(f *modfile.File) error {
+			for _, dep := range deps {
+				modPath, ver := splitDependency(dep)
+				if err := f.AddRequire(modPath, ver); err != nil {
+					return fmt.Errorf("unable to add require (%s): %s", dep, err)
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// WithTemplateDir points the generator at a directory of external `.tpl` files that is
+// searched before the embedded templates. Any template name found there replaces the
+// builtin one; templates missing from dir fall back to the embedded FS.
+func (a App) WithTemplateDir(dir string) setupOp {
+	a.settings[templateDir] = dir
+	return noOp("WithTemplateDir")
+}
+
+// WithTemplateOverlay is the programmatic equivalent of WithTemplateDir for callers that
+// already have their templates in an fs.FS (e.g. another //go:embed). It is searched
+// before both WithTemplateDir and the embedded templates.
+func (a App) WithTemplateOverlay(overlay fs.FS) setupOp {
+	a.settings[templateOverlay] = overlay
+	return noOp("WithTemplateOverlay")
+}
+
+// WithCustomTemplate registers an additional template, found via WithTemplateDir or
+// WithTemplateOverlay, to render alongside the builtins. name is resolved the same way as
+// any builtin template name; outputPath is where the rendered result is written, relative
+// to the current working directory.
+func (App) WithCustomTemplate(name string, outputPath string, args map[string]any) setupOp {
+	const opName = "WithCustomTemplate"
+	return newOp(opName, func(a App) error {
+		outputSubDir, outputName := path.Split(outputPath)
+		return generateTemplate(generateTemplateArgs{
+			opName:         opName,
+			app:            a,
+			fileType:       customFileType(outputPath),
+			outputName:     outputName,
+			outputSubDir:   strings.TrimSuffix(outputSubDir, "/"),
+			templateName:   name,
+			templateSubDir: "",
+			templateArgs:   args,
+		})
+	})
+}
+
+// customFileType picks the warning/hash comment style for a WithCustomTemplate output path
+// based on its extension, the same way the builtin fileType values do, falling back to
+// "custom" ("#"-style) for extensions with no better match.
+func customFileType(outputPath string) string {
+	switch path.Ext(outputPath) {
+	case ".go":
+		return "go"
+	case ".yml", ".yaml":
+		return "yml"
+	case ".toml":
+		return "toml"
+	default:
+		return "custom"
+	}
+}
+
+// resolver builds the templateResolver for the current App settings.
+func (a App) resolver() *templateResolver {
+	var overlay fs.FS
+	if o, ok := a.settings[templateOverlay]; ok {
+		overlay = o.(fs.FS)
+	}
+
+	var dir string
+	if d, ok := a.settings[templateDir]; ok {
+		dir = d.(string)
+	}
+
+	return newTemplateResolver(overlay, dir, templatesFS)
 }
 
 // WithGithubWorkflows sets up the specified workflows.
@@ -183,42 +331,51 @@ func (a App) WithDependencies(deps ...string) setupOp {
 //   - linter - on pull request for all branches
 //   - test - on pull request for all branches
 func (App) WithGithubWorkflows(flows ...string) setupOp {
-	return func(a App) error {
+	const opName = "WithGithubWorkflows"
+	return newOp(opName, func(a App) error {
 		tmplArgs := templateArgs{}
-		if ver, ok := a.settings[goVersion]; !ok {
-			panic(fmt.Errorf("no %s provided - please call WithGoVersion", goVersion))
-		} else {
-			tmplArgs.GoVersion = ver.(string)
+		ver, ok := a.settings[goVersion]
+		if !ok {
+			return fmt.Errorf("no %s provided - please call WithGoVersion", goVersion)
 		}
+		tmplArgs.GoVersion = ver.(string)
 
 		linterPresent := false
 		for _, name := range flows {
 			if name == "linter" {
 				linterPresent = true
 			}
-			generateTemplate(generateTemplateArgs{
+			if err := generateTemplate(generateTemplateArgs{
+				opName:         opName,
+				app:            a,
 				fileType:       "yml",
 				outputName:     name + ".yml",
 				outputSubDir:   path.Join(".github", "workflows"),
 				templateArgs:   tmplArgs,
 				templateName:   name + templateExts["yml"],
 				templateSubDir: "github_workflows",
-			})
+			}); err != nil {
+				return err
+			}
 		}
 
 		if linterPresent {
-			generateTemplate(generateTemplateArgs{
+			if err := generateTemplate(generateTemplateArgs{
+				opName:         opName,
+				app:            a,
 				fileType:       "toml",
 				outputName:     ".golangci.toml",
 				outputSubDir:   "",
 				templateArgs:   templateArgs{},
 				templateName:   ".golangci.toml",
 				templateSubDir: "",
-			})
+			}); err != nil {
+				return err
+			}
 		}
 
 		return nil
-	}
+	})
 }
 
 // WithGoVersion provide the current version of Go to use for GitHub actions
@@ -226,24 +383,27 @@ func (App) WithGithubWorkflows(flows ...string) setupOp {
 func (a App) WithGoVersion(ver string) setupOp {
 	a.settings[goVersion] = ver
 
-	return func(_ App) error {
-		_, err := os.Stat(goModFile)
-		if err == nil { // we have a go mod file, and we can replace the version
-			data, err := os.ReadFile(goModFile)
-			if err != nil {
-				return fmt.Errorf("unable to read go.mod file (%s): %s", goModFile, err)
+	return newOp("WithGoVersion", func(_ App) error {
+		return updateGoMod(func(f *modfile.File) error {
+			if err := f.AddGoStmt(ver); err != nil {
+				return fmt.Errorf("unable to set go version (%s) in go.mod: %s", ver, err)
 			}
+			return nil
+		})
+	})
+}
 
-			pattern := regexp.MustCompile(`(?m)$\s*go \d+\.\d+(\.\d+)?\s*$`)
-			newData := pattern.ReplaceAll(data, []byte("\n\ngo "+ver+"\n"))
-
-			err = os.WriteFile(goModFile, newData, newFilePermission)
-			if err != nil {
-				return fmt.Errorf("unable to write go.mod file (%s): %s", goModFile, err)
+// WithToolchain sets the "toolchain" directive in go.mod, pinning the exact Go toolchain
+// used to build the module (e.g. "go1.23.1") independent of the minimum WithGoVersion.
+func (App) WithToolchain(ver string) setupOp {
+	return newOp("WithToolchain", func(_ App) error {
+		return updateGoMod(func(f *modfile.File) error {
+			if err := f.AddToolchainStmt(ver); err != nil {
+				return fmt.Errorf("unable to set toolchain (%s) in go.mod: %s", ver, err)
 			}
-		}
-		return nil
-	}
+			return nil
+		})
+	})
 }
 
 // WithMakefile creates the basic Makefile with:
@@ -256,7 +416,8 @@ func (a App) WithGoVersion(ver string) setupOp {
 // Each string will be concatenated with "Makefile.". This allows for custom "make" commands
 // for a project. These customer make files will not be generated nor effected by app-init.
 func (App) WithMakefile(makeExt ...string) setupOp {
-	return func(a App) error {
+	const opName = "WithMakefile"
+	return newOp(opName, func(a App) error {
 		nodes, err := templatesFS.ReadDir(path.Join(templateBaseDir, mkfilesSubDir))
 		if err != nil {
 			return fmt.Errorf("unable to read dir (%s): %s", mkfilesSubDir, err)
@@ -270,7 +431,11 @@ func (App) WithMakefile(makeExt ...string) setupOp {
 		if deps, ok := a.settings[dependencies]; ok {
 			depString := ""
 			for _, dep := range deps.([]string) {
-				depString = fmt.Sprintf("%sgo get -u %s@latest\n\t", depString, dep)
+				modPath, ver := splitDependency(dep)
+				if ver == defaultDependencyVersion {
+					ver = "latest"
+				}
+				depString = fmt.Sprintf("%sgo get -u %s@%s\n\t", depString, modPath, ver)
 			}
 			tmplArgs.Dependencies = depString
 		}
@@ -280,30 +445,37 @@ func (App) WithMakefile(makeExt ...string) setupOp {
 		}
 
 		for _, node := range nodes {
-			generateTemplate(generateTemplateArgs{
+			if err := generateTemplate(generateTemplateArgs{
+				opName:         opName,
+				app:            a,
 				fileType:       "mkfile",
 				outputName:     node.Name()[:len(node.Name())-len(templateExts["mkfile"])],
 				outputSubDir:   "",
 				templateName:   node.Name(),
 				templateSubDir: mkfilesSubDir,
 				templateArgs:   tmplArgs,
-			})
+			}); err != nil {
+				return err
+			}
 		}
 		return nil
-	}
+	})
 }
 
 type generateTemplateArgs struct {
-	fileType       string       // type of file that the template is for the correct warning message
-	outputName     string       // name of the template in its final form
-	outputSubDir   string       // sub dir added to root dir for the final file
-	templateName   string       // name of the template file
-	templateSubDir string       // sub dir added to the template base dir to find template
-	templateArgs   templateArgs // args that are fed to text/template
+	opName         string // name of the With* op generating this file, for Event.OpName
+	app            App    // App the template is being generated for, used to resolve templates
+	fileType       string // type of file that the template is for the correct warning message
+	outputName     string // name of the template in its final form
+	outputSubDir   string // sub dir added to root dir for the final file
+	templateName   string // name of the template file
+	templateSubDir string // sub dir added to the template base dir to find template
+	templateArgs   any    // args that are fed to text/template
 }
 type templateArgs struct {
 	BinaryName   string // name of the executable program
 	BuildEnvArgs string // any env args that are needed when building the app
+	CodeOwners   string // see WithCodeOwners
 	ConfigName   string // name of the config element for the main program
 	Dependencies string // see WithDependencies
 	GoVersion    string // see WithGoVersion
@@ -311,34 +483,66 @@ type templateArgs struct {
 	PackageName  string // name of the package
 }
 
-func generateTemplate(args generateTemplateArgs) {
+func generateTemplate(args generateTemplateArgs) error {
 	inputFileName := path.Join(templateBaseDir, args.templateSubDir, args.templateName)
 	outputFileName := path.Join(args.outputSubDir, args.outputName)
 
-	if args.outputSubDir != "" {
-		err := os.MkdirAll(args.outputSubDir, newDirPermission)
-		if err != nil {
-			panic(fmt.Errorf("unable to create directory structure (%s): %s", args.outputSubDir, err))
-		}
+	event := Event{OpName: args.opName, OutputPath: outputFileName, TemplateName: args.templateName}
+	fail := func(err error) error {
+		event.Action, event.Err = ActionFailed, err
+		args.app.emit(event)
+		return err
 	}
-	//nolint:gosec // G304 -- This is safe as the file is being opened for write/create/truncation - no reading
-	f, err := os.OpenFile(outputFileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, newFilePermission)
+
+	temp, err := template.ParseFS(args.app.resolver(), inputFileName)
 	if err != nil {
-		panic(fmt.Errorf("unable to create file (%s): %s", outputFileName, err))
+		return fail(fmt.Errorf("unable to parse template (%s): %s", inputFileName, err))
 	}
-	defer func() {
-		err := f.Close()
-		if err != nil {
-			panic(fmt.Errorf("error closing file (%s): %s", outputFileName, err))
+
+	var rendered bytes.Buffer
+	if err := temp.Execute(&rendered, args.templateArgs); err != nil {
+		return fail(fmt.Errorf("unable to execute template (%s): %s", inputFileName, err))
+	}
+	body := rendered.Bytes()
+
+	existing, readErr := os.ReadFile(outputFileName)
+	hasExisting := readErr == nil
+
+	mode := args.app.mode()
+	if mode == ModeMerge && hasExisting {
+		body = mergeUserRegions(body, userRegions(existing))
+	}
+
+	newContent := append([]byte(warnings[args.fileType]+hashLine(args.fileType, body)), body...)
+
+	if hasExisting {
+		// The recorded hash alone is not enough: a hand-edited body keeps the old hash line,
+		// so the file on disk must also match what would be written.
+		if hash, ok := existingHash(existing); ok && hash == sha256Hex(body) && bytes.Equal(existing, newContent) {
+			event.Action = ActionSkipped
+			args.app.emit(event)
+			return nil // rendered content is unchanged, nothing to do
 		}
-	}()
-	_, err = f.WriteString(warnings[args.fileType])
-	if err != nil {
-		panic(fmt.Errorf("unable to write warning to file (%s): %s", outputFileName, err))
 	}
-	temp := template.Must(template.ParseFS(templatesFS, inputFileName))
-	err = temp.Execute(f, args.templateArgs)
-	if err != nil {
-		panic(fmt.Errorf("unable to execute template (%s): %s", inputFileName, err))
+
+	if mode == ModeDryRun {
+		fmt.Print(unifiedDiff(outputFileName, existing, newContent))
+		event.Action = ActionSkipped
+		args.app.emit(event)
+		return nil
 	}
+
+	if args.outputSubDir != "" {
+		if err := os.MkdirAll(args.outputSubDir, newDirPermission); err != nil {
+			return fail(fmt.Errorf("unable to create directory structure (%s): %s", args.outputSubDir, err))
+		}
+	}
+
+	if err := os.WriteFile(outputFileName, newContent, newFilePermission); err != nil {
+		return fail(fmt.Errorf("unable to write file (%s): %s", outputFileName, err))
+	}
+
+	event.Action = ActionWrote
+	args.app.emit(event)
+	return nil
 }
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestGenerateAggregatesErrors(t *testing.T) {
+	chdir(t, t.TempDir())
+
+	overlay := fstest.MapFS{
+		"package/config.go.tpl": {Data: []byte("package {{.PackageName}}\n")},
+	}
+
+	a := NewApp("widget", "widget")
+	app := a.SetupApp(
+		a.WithTemplateOverlay(overlay),
+		a.WithPackages("logger"),                                // succeeds: template provided via the overlay
+		a.WithCustomTemplate("missing.tpl", "missing.txt", nil), // fails: "missing.tpl" is neither in the overlay nor embedded
+		a.WithCodeOwners("@skeletonkey"),                        // fails: "codeowners.tpl" isn't in the overlay either
+	)
+
+	err := app.Generate()
+	if err == nil {
+		t.Fatal("Generate() = nil error, want one (WithCustomTemplate and WithCodeOwners have no template available)")
+	}
+
+	for _, opName := range []string{"WithCustomTemplate", "WithCodeOwners"} {
+		if !strings.Contains(err.Error(), opName) {
+			t.Errorf("Generate() error = %q, want it to mention %s", err, opName)
+		}
+	}
+	if strings.Contains(err.Error(), "WithPackages") {
+		t.Errorf("Generate() error = %q, did not expect WithPackages to appear since it succeeded", err)
+	}
+}
+
+func TestGenerateWithHookEvents(t *testing.T) {
+	chdir(t, t.TempDir())
+
+	overlay := fstest.MapFS{
+		"package/config.go.tpl": {Data: []byte("package {{.PackageName}}\n")},
+	}
+
+	var events []Event
+	record := func(ev Event) { events = append(events, ev) }
+
+	a := NewApp("widget", "widget")
+	app := a.SetupApp(
+		a.WithHook(record),
+		a.WithTemplateOverlay(overlay),
+		a.WithPackages("logger"),
+		a.WithCodeOwners("@skeletonkey"), // no "codeowners.tpl" in the overlay: should fail and still emit
+	)
+
+	if err := app.Generate(); err == nil {
+		t.Fatal("Generate() = nil error, want one (WithCodeOwners has no template available)")
+	}
+
+	wrote, failed := findEvent(events, "WithPackages"), findEvent(events, "WithCodeOwners")
+	if wrote == nil || wrote.Action != ActionWrote {
+		t.Fatalf("WithPackages event = %+v, want an ActionWrote event", wrote)
+	}
+	if failed == nil || failed.Action != ActionFailed || failed.Err == nil {
+		t.Fatalf("WithCodeOwners event = %+v, want an ActionFailed event with a non-nil Err", failed)
+	}
+
+	// Regenerating unchanged content should report ActionSkipped rather than ActionWrote.
+	events = nil
+	if err := app.Generate(); err == nil {
+		t.Fatal("Generate() = nil error, want one (WithCodeOwners still has no template available)")
+	}
+	skipped := findEvent(events, "WithPackages")
+	if skipped == nil || skipped.Action != ActionSkipped {
+		t.Fatalf("WithPackages event on second run = %+v, want an ActionSkipped event", skipped)
+	}
+}
+
+func findEvent(events []Event, opName string) *Event {
+	for i := range events {
+		if events[i].OpName == opName {
+			return &events[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"fmt"
+	"path"
+
+	"gopkg.in/yaml.v3"
+)
+
+// projectsSubDir is where built-in Template manifests live, under templateBaseDir.
+const projectsSubDir = "projects"
+
+// manifestFileName is the per-template file describing which With* ops to run.
+const manifestFileName = "manifest.yaml"
+
+// projectManifest is the on-disk shape of templates/projects/<name>/manifest.yaml: an
+// ordered list of With* calls and the string arguments to invoke them with.
+type projectManifest struct {
+	Archetype string `yaml:"archetype"`
+	Ops       []struct {
+		Op   string   `yaml:"op"`
+		Args []string `yaml:"args"`
+	} `yaml:"ops"`
+}
+
+// ListTemplates returns the names of the built-in project archetypes (e.g. "cli-app",
+// "http-service") available to NewAppFromTemplate.
+func ListTemplates() ([]string, error) {
+	entries, err := templatesFS.ReadDir(path.Join(templateBaseDir, projectsSubDir))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list templates: %s", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// NewAppFromTemplate builds an App wired up exactly as the named built-in template
+// describes, ready to Generate().
+func NewAppFromTemplate(name string, binaryName string, dir string) (App, error) {
+	manifest, err := loadManifest(name)
+	if err != nil {
+		return App{}, err
+	}
+
+	a := NewApp(binaryName, dir)
+	ops := make([]setupOp, 0, len(manifest.Ops))
+	for _, opSpec := range manifest.Ops {
+		op, err := a.resolveTemplateOp(opSpec.Op, opSpec.Args)
+		if err != nil {
+			return App{}, fmt.Errorf("template %q: %s", name, err)
+		}
+		ops = append(ops, op)
+	}
+
+	return a.SetupApp(ops...), nil
+}
+
+// TemplateDefaults extracts the GoVersion/workflows/packages a built-in template would set
+// up, so a caller (e.g. an interactive CLI prompt) can offer them as pre-filled defaults
+// before binaryName/dir are known.
+func TemplateDefaults(name string) (goVersion string, workflows []string, packages []string, err error) {
+	manifest, err := loadManifest(name)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	for _, opSpec := range manifest.Ops {
+		switch opSpec.Op {
+		case "WithGoVersion":
+			if len(opSpec.Args) == 1 {
+				goVersion = opSpec.Args[0]
+			}
+		case "WithGithubWorkflows":
+			workflows = opSpec.Args
+		case "WithPackages":
+			packages = opSpec.Args
+		}
+	}
+	return goVersion, workflows, packages, nil
+}
+
+func loadManifest(name string) (*projectManifest, error) {
+	data, err := templatesFS.ReadFile(path.Join(templateBaseDir, projectsSubDir, name, manifestFileName))
+	if err != nil {
+		return nil, fmt.Errorf("unknown template %q: %s", name, err)
+	}
+
+	manifest := &projectManifest{}
+	if err := yaml.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("unable to parse manifest for template %q: %s", name, err)
+	}
+	return manifest, nil
+}
+
+// resolveTemplateOp maps a manifest op name to the corresponding With* call. Supported ops
+// are WithGoVersion, WithMakefile, WithGithubWorkflows, WithPackages, WithCodeOwners,
+// WithDependencies, WithConfig and WithCGOEnabled; any other op name is an error.
+func (a App) resolveTemplateOp(op string, args []string) (setupOp, error) {
+	switch op {
+	case "WithGoVersion":
+		if len(args) != 1 {
+			return setupOp{}, fmt.Errorf("%s takes exactly one argument", op)
+		}
+		return a.WithGoVersion(args[0]), nil
+	case "WithMakefile":
+		return a.WithMakefile(args...), nil
+	case "WithGithubWorkflows":
+		return a.WithGithubWorkflows(args...), nil
+	case "WithPackages":
+		return a.WithPackages(args...), nil
+	case "WithCodeOwners":
+		return a.WithCodeOwners(args...), nil
+	case "WithDependencies":
+		return a.WithDependencies(args...), nil
+	case "WithConfig":
+		return a.WithConfig(), nil
+	case "WithCGOEnabled":
+		return a.WithCGOEnabled(), nil
+	default:
+		return setupOp{}, fmt.Errorf("unsupported template op %q", op)
+	}
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestListTemplates(t *testing.T) {
+	got, err := ListTemplates()
+	if err != nil {
+		t.Fatalf("ListTemplates() returned error: %s", err)
+	}
+
+	sort.Strings(got)
+	want := []string{"cli-app", "cron-worker", "http-service", "library"}
+	if len(got) != len(want) {
+		t.Fatalf("ListTemplates() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ListTemplates()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTemplateDefaults(t *testing.T) {
+	t.Run("http-service and cron-worker have distinct packages", func(t *testing.T) {
+		_, _, httpPackages, err := TemplateDefaults("http-service")
+		if err != nil {
+			t.Fatalf("TemplateDefaults(http-service) returned error: %s", err)
+		}
+		_, _, cronPackages, err := TemplateDefaults("cron-worker")
+		if err != nil {
+			t.Fatalf("TemplateDefaults(cron-worker) returned error: %s", err)
+		}
+
+		if equalStrings(httpPackages, cronPackages) {
+			t.Errorf("http-service and cron-worker have identical packages %v, want them distinguished", httpPackages)
+		}
+	})
+
+	t.Run("library has no packages or config, just Go version, workflows and a Makefile", func(t *testing.T) {
+		goVersion, workflows, packages, err := TemplateDefaults("library")
+		if err != nil {
+			t.Fatalf("TemplateDefaults(library) returned error: %s", err)
+		}
+		if goVersion != "1.23" {
+			t.Errorf("goVersion = %q, want 1.23", goVersion)
+		}
+		if len(workflows) != 2 {
+			t.Errorf("workflows = %v, want 2 entries", workflows)
+		}
+		if len(packages) != 0 {
+			t.Errorf("packages = %v, want none", packages)
+		}
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		if _, _, _, err := TemplateDefaults("does-not-exist"); err == nil {
+			t.Fatal("TemplateDefaults(does-not-exist) = nil error, want one")
+		}
+	})
+}
+
+func TestNewAppFromTemplate(t *testing.T) {
+	t.Run("unknown template", func(t *testing.T) {
+		if _, err := NewAppFromTemplate("does-not-exist", "widget", "widget"); err == nil {
+			t.Fatal("NewAppFromTemplate(does-not-exist) = nil error, want one")
+		}
+	})
+
+	t.Run("known template builds without error", func(t *testing.T) {
+		if _, err := NewAppFromTemplate("cli-app", "widget", "widget"); err != nil {
+			t.Fatalf("NewAppFromTemplate(cli-app) returned error: %s", err)
+		}
+	})
+}
+
+func TestResolveTemplateOp(t *testing.T) {
+	a := NewApp("widget", "widget")
+
+	t.Run("unsupported op", func(t *testing.T) {
+		if _, err := a.resolveTemplateOp("WithNoSuchThing", nil); err == nil {
+			t.Fatal("resolveTemplateOp(WithNoSuchThing) = nil error, want one")
+		}
+	})
+
+	t.Run("WithGoVersion requires exactly one argument", func(t *testing.T) {
+		if _, err := a.resolveTemplateOp("WithGoVersion", []string{"1.23", "1.24"}); err == nil {
+			t.Fatal("resolveTemplateOp(WithGoVersion, 2 args) = nil error, want one")
+		}
+		if _, err := a.resolveTemplateOp("WithGoVersion", []string{"1.23"}); err != nil {
+			t.Errorf("resolveTemplateOp(WithGoVersion, 1 arg) returned error: %s", err)
+		}
+	})
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// templateResolver finds template content by name, preferring a caller-supplied overlay FS,
+// then an external template directory, and finally falling back to the embedded builtin
+// templates. It implements fs.FS so it can be handed straight to template.ParseFS.
+type templateResolver struct {
+	overlay  fs.FS // WithTemplateOverlay, nil if unset
+	external fs.FS // os.DirFS(WithTemplateDir path), nil if unset
+	embedded fs.FS
+}
+
+// newTemplateResolver builds a resolver for the given App settings.
+func newTemplateResolver(overlay fs.FS, dir string, embedded fs.FS) *templateResolver {
+	r := &templateResolver{overlay: overlay, embedded: embedded}
+	if dir != "" {
+		r.external = os.DirFS(dir)
+	}
+	return r
+}
+
+// Open returns the named template, checked against the overlay and external directory
+// (both rooted relative to templateBaseDir) before falling back to the embedded FS.
+func (r *templateResolver) Open(name string) (fs.File, error) {
+	relName := strings.TrimPrefix(name, templateBaseDir+"/")
+
+	if r.overlay != nil {
+		if f, err := r.overlay.Open(relName); err == nil {
+			return f, nil
+		}
+	}
+
+	if r.external != nil {
+		if f, err := r.external.Open(relName); err == nil {
+			return f, nil
+		}
+	}
+
+	return r.embedded.Open(name)
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestTemplateResolverOpen(t *testing.T) {
+	embedded := fstest.MapFS{
+		"templates/greeting.tpl": {Data: []byte("embedded")},
+	}
+
+	t.Run("falls back to embedded when no overlay or dir is set", func(t *testing.T) {
+		resolver := newTemplateResolver(nil, "", embedded)
+		assertOpenContent(t, resolver, "templates/greeting.tpl", "embedded")
+	})
+
+	t.Run("external dir overrides embedded", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "greeting.tpl"), []byte("external"), 0644); err != nil {
+			t.Fatalf("unable to write external template: %s", err)
+		}
+
+		resolver := newTemplateResolver(nil, dir, embedded)
+		assertOpenContent(t, resolver, "templates/greeting.tpl", "external")
+	})
+
+	t.Run("overlay overrides both external dir and embedded", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "greeting.tpl"), []byte("external"), 0644); err != nil {
+			t.Fatalf("unable to write external template: %s", err)
+		}
+		overlay := fstest.MapFS{
+			"greeting.tpl": {Data: []byte("overlay")},
+		}
+
+		resolver := newTemplateResolver(overlay, dir, embedded)
+		assertOpenContent(t, resolver, "templates/greeting.tpl", "overlay")
+	})
+
+	t.Run("external dir missing the template falls back to embedded", func(t *testing.T) {
+		resolver := newTemplateResolver(nil, t.TempDir(), embedded)
+		assertOpenContent(t, resolver, "templates/greeting.tpl", "embedded")
+	})
+}
+
+func assertOpenContent(t *testing.T, resolver *templateResolver, name string, want string) {
+	t.Helper()
+
+	f, err := resolver.Open(name)
+	if err != nil {
+		t.Fatalf("Open(%q) returned error: %s", name, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	got, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("unable to read opened file: %s", err)
+	}
+	if string(got) != want {
+		t.Errorf("Open(%q) = %q, want %q", name, got, want)
+	}
+}
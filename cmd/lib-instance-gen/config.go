@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// configFileName is the default project file name looked for by "generate" and written by "init".
+const configFileName = ".lib-instance-gen.toml"
+
+// projectConfig is the on-disk declarative equivalent of a hand-written init.go: one section per
+// With* call on app.App.
+type projectConfig struct {
+	App struct {
+		BinaryName string `toml:"binary_name" yaml:"binary_name"`
+		Dir        string `toml:"dir"         yaml:"dir"`
+	} `toml:"app" yaml:"app"`
+
+	Makefile struct {
+		Includes []string `toml:"includes" yaml:"includes"`
+	} `toml:"makefile" yaml:"makefile"`
+
+	Workflows struct {
+		Enabled []string `toml:"enabled" yaml:"enabled"`
+	} `toml:"workflows" yaml:"workflows"`
+
+	Go struct {
+		Version string `toml:"version" yaml:"version"`
+	} `toml:"go" yaml:"go"`
+
+	Packages []struct {
+		Name string `toml:"name" yaml:"name"`
+	} `toml:"packages" yaml:"packages"`
+
+	CodeOwners struct {
+		Lines []string `toml:"lines" yaml:"lines"`
+	} `toml:"codeowners" yaml:"codeowners"`
+
+	Dependencies struct {
+		GoLib []string `toml:"golib" yaml:"golib"`
+	} `toml:"dependencies" yaml:"dependencies"`
+}
+
+// loadConfig reads and parses the project file at path, dispatching on its extension.
+func loadConfig(path string) (*projectConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read project file (%s): %s", path, err)
+	}
+
+	cfg := &projectConfig{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".toml":
+		if err := toml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("unable to parse project file (%s): %s", path, err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("unable to parse project file (%s): %s", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported project file extension (%s): expected .toml, .yaml, or .yml", ext)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("toml", func(t *testing.T) {
+		path := writeConfigFile(t, "project.toml", `
+[app]
+binary_name = "widget"
+dir = "widget"
+
+[go]
+version = "1.23"
+
+[workflows]
+enabled = ["linter", "test"]
+`)
+
+		cfg, err := loadConfig(path)
+		if err != nil {
+			t.Fatalf("loadConfig returned error: %s", err)
+		}
+		if cfg.App.BinaryName != "widget" || cfg.App.Dir != "widget" {
+			t.Errorf("cfg.App = %+v, want BinaryName/Dir = widget", cfg.App)
+		}
+		if cfg.Go.Version != "1.23" {
+			t.Errorf("cfg.Go.Version = %q, want 1.23", cfg.Go.Version)
+		}
+		if len(cfg.Workflows.Enabled) != 2 {
+			t.Errorf("cfg.Workflows.Enabled = %v, want 2 entries", cfg.Workflows.Enabled)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		path := writeConfigFile(t, "project.yaml", `
+app:
+  binary_name: widget
+  dir: widget
+go:
+  version: "1.23"
+`)
+
+		cfg, err := loadConfig(path)
+		if err != nil {
+			t.Fatalf("loadConfig returned error: %s", err)
+		}
+		if cfg.App.BinaryName != "widget" || cfg.App.Dir != "widget" {
+			t.Errorf("cfg.App = %+v, want BinaryName/Dir = widget", cfg.App)
+		}
+		if cfg.Go.Version != "1.23" {
+			t.Errorf("cfg.Go.Version = %q, want 1.23", cfg.Go.Version)
+		}
+	})
+
+	t.Run("yml extension", func(t *testing.T) {
+		path := writeConfigFile(t, "project.yml", "app:\n  binary_name: widget\n")
+
+		cfg, err := loadConfig(path)
+		if err != nil {
+			t.Fatalf("loadConfig returned error: %s", err)
+		}
+		if cfg.App.BinaryName != "widget" {
+			t.Errorf("cfg.App.BinaryName = %q, want widget", cfg.App.BinaryName)
+		}
+	})
+
+	t.Run("unsupported extension", func(t *testing.T) {
+		path := writeConfigFile(t, "project.ini", "binary_name=widget\n")
+
+		if _, err := loadConfig(path); err == nil {
+			t.Fatal("loadConfig with an unsupported extension = nil error, want one")
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := loadConfig(filepath.Join(t.TempDir(), "missing.toml")); err == nil {
+			t.Fatal("loadConfig on a missing file = nil error, want one")
+		}
+	})
+}
+
+// writeConfigFile writes contents to name inside a fresh temp dir and returns its path.
+func writeConfigFile(t *testing.T, name string, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("unable to write %s: %s", name, err)
+	}
+	return path
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+
+	instanceGen "github.com/skeletonkey/lib-instance-gen-go/app"
+)
+
+// runGenerate loads the project file and runs the same App/With* pipeline a hand-written
+// init.go would.
+func runGenerate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	configPath := fs.String("config", configFileName, "path to the project file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+
+	return buildApp(*cfg).Generate()
+}
+
+// buildApp translates a projectConfig into an App ready to Generate().
+func buildApp(cfg projectConfig) instanceGen.App {
+	app := instanceGen.NewApp(cfg.App.BinaryName, cfg.App.Dir)
+
+	packageNames := make([]string, 0, len(cfg.Packages))
+	for _, pkg := range cfg.Packages {
+		packageNames = append(packageNames, pkg.Name)
+	}
+
+	return app.SetupApp(
+		app.WithGoVersion(cfg.Go.Version),
+		app.WithGithubWorkflows(cfg.Workflows.Enabled...),
+		app.WithMakefile(cfg.Makefile.Includes...),
+		app.WithPackages(packageNames...),
+		app.WithCodeOwners(cfg.CodeOwners.Lines...),
+		app.WithDependencies(cfg.Dependencies.GoLib...),
+	)
+}
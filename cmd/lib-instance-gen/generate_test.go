@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestBuildApp checks that buildApp actually wires projectConfig fields into the
+// corresponding App ops, by running Generate in an empty directory (no go.mod, no
+// on-disk templates) and inspecting which op names show up in the aggregated error:
+// an op that never ran because buildApp dropped its config can't fail.
+func TestBuildApp(t *testing.T) {
+	t.Run("only the always-on Makefile op fails for an empty config", func(t *testing.T) {
+		chdir(t, t.TempDir())
+
+		cfg := projectConfig{}
+		cfg.App.BinaryName = "widget"
+		cfg.App.Dir = "widget"
+
+		err := buildApp(cfg).Generate()
+		if err == nil {
+			t.Fatal("Generate() = nil error, want one (no templates on disk in this environment)")
+		}
+		if !strings.Contains(err.Error(), "WithMakefile") {
+			t.Errorf("Generate() error = %q, want it to mention WithMakefile", err)
+		}
+		for _, opName := range []string{"WithGithubWorkflows", "WithPackages", "WithCodeOwners"} {
+			if strings.Contains(err.Error(), opName) {
+				t.Errorf("Generate() error = %q, did not expect %s to run for an empty config", err, opName)
+			}
+		}
+	})
+
+	t.Run("populated config runs every corresponding op", func(t *testing.T) {
+		chdir(t, t.TempDir())
+
+		cfg := projectConfig{}
+		cfg.App.BinaryName = "widget"
+		cfg.App.Dir = "widget"
+		cfg.Go.Version = "1.23"
+		cfg.Workflows.Enabled = []string{"linter", "test"}
+		cfg.Packages = []struct {
+			Name string `toml:"name" yaml:"name"`
+		}{{Name: "logger"}}
+		cfg.CodeOwners.Lines = []string{"@skeletonkey"}
+
+		err := buildApp(cfg).Generate()
+		if err == nil {
+			t.Fatal("Generate() = nil error, want one (no templates on disk in this environment)")
+		}
+		for _, opName := range []string{"WithMakefile", "WithGithubWorkflows", "WithPackages", "WithCodeOwners"} {
+			if !strings.Contains(err.Error(), opName) {
+				t.Errorf("Generate() error = %q, want it to mention %s", err, opName)
+			}
+		}
+	})
+}
+
+// chdir switches to dir for the duration of the test, restoring the original working
+// directory on cleanup.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("unable to get working directory: %s", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("unable to chdir to %s: %s", dir, err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(orig)
+	})
+}
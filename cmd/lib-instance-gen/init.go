@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	instanceGen "github.com/skeletonkey/lib-instance-gen-go/app"
+)
+
+// configTemplate renders a .lib-instance-gen.toml from an initAnswers.
+var configTemplate = template.Must(template.New("config").Parse(`[app]
+binary_name = "{{.BinaryName}}"
+dir = "app"
+
+[makefile]
+includes = []
+
+[workflows]
+enabled = [{{range $i, $w := .Workflows}}{{if $i}}, {{end}}"{{$w}}"{{end}}]
+
+[go]
+version = "{{.GoVersion}}"
+{{range .Packages}}
+[[packages]]
+name = "{{.}}"
+{{end}}
+[codeowners]
+lines = []
+
+[dependencies]
+golib = []
+`))
+
+// defaultInitGo is written alongside the config so existing users can migrate their
+// hand-written init.go without losing the entrypoint shape they already have.
+const defaultInitGo = `package main
+
+func main() {
+	// lib-instance-gen now reads ` + "`" + configFileName + "`" + ` directly - run
+	// "lib-instance-gen generate" instead of calling instanceGen from here.
+}
+`
+
+// initAnswers is everything needed to render configTemplate.
+type initAnswers struct {
+	BinaryName string
+	GoVersion  string
+	Workflows  []string
+	Packages   []string
+}
+
+// runInit scaffolds a project file (and a stub init.go) in dir. With --template it uses a
+// built-in archetype's defaults non-interactively; without it, it prompts for everything.
+func runInit(args []string) error {
+	fs := flag.NewFlagSet("init", flag.ExitOnError)
+	templateName := fs.String("template", "", "built-in project template to scaffold from (see instanceGen.ListTemplates)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("expected exactly one argument: the directory to scaffold")
+	}
+	dir := fs.Arg(0)
+
+	answers, err := gatherAnswers(dir, *templateName)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("unable to create directory (%s): %s", dir, err)
+	}
+
+	var rendered strings.Builder
+	if err := configTemplate.Execute(&rendered, answers); err != nil {
+		return fmt.Errorf("unable to render project file: %s", err)
+	}
+
+	configPath := filepath.Join(dir, configFileName)
+	if err := writeIfAbsent(configPath, rendered.String()); err != nil {
+		return err
+	}
+
+	initGoPath := filepath.Join(dir, "init.go")
+	return writeIfAbsent(initGoPath, defaultInitGo)
+}
+
+// gatherAnswers resolves the config content to scaffold: non-interactively from a named
+// template, or by prompting the user (picking a template, then confirming/overriding its
+// defaults) when templateName is empty.
+func gatherAnswers(dir string, templateName string) (initAnswers, error) {
+	if templateName != "" {
+		goVersion, workflows, packages, err := instanceGen.TemplateDefaults(templateName)
+		if err != nil {
+			return initAnswers{}, err
+		}
+		return initAnswers{
+			BinaryName: filepath.Base(dir),
+			GoVersion:  goVersion,
+			Workflows:  workflows,
+			Packages:   packages,
+		}, nil
+	}
+
+	return promptAnswers(dir)
+}
+
+// promptAnswers drives the interactive "init" flow: pick a template, then confirm or
+// override its binary name/Go version/packages/workflows.
+func promptAnswers(dir string) (initAnswers, error) {
+	templates, err := instanceGen.ListTemplates()
+	if err != nil {
+		return initAnswers{}, err
+	}
+
+	r := bufio.NewReader(os.Stdin)
+
+	fmt.Println("Available templates:")
+	for i, name := range templates {
+		fmt.Printf("  %d) %s\n", i+1, name)
+	}
+	choice, err := prompt(r, "Template number", "1", func(s string) error {
+		n, convErr := strconv.Atoi(s)
+		if convErr != nil || n < 1 || n > len(templates) {
+			return fmt.Errorf("enter a number between 1 and %d", len(templates))
+		}
+		return nil
+	})
+	if err != nil {
+		return initAnswers{}, err
+	}
+	n, _ := strconv.Atoi(choice)
+	templateName := templates[n-1]
+
+	goVersion, workflows, packages, err := instanceGen.TemplateDefaults(templateName)
+	if err != nil {
+		return initAnswers{}, err
+	}
+
+	binaryName, err := prompt(r, "Binary name", filepath.Base(dir), validateBinaryName)
+	if err != nil {
+		return initAnswers{}, err
+	}
+
+	goVersion, err = prompt(r, "Go version", goVersion, validateGoVersion)
+	if err != nil {
+		return initAnswers{}, err
+	}
+
+	packages, err = promptList(r, "Packages (comma-separated)", packages, validatePackageName)
+	if err != nil {
+		return initAnswers{}, err
+	}
+
+	workflows, err = promptList(r, "Workflows (comma-separated)", workflows, func(string) error { return nil })
+	if err != nil {
+		return initAnswers{}, err
+	}
+
+	return initAnswers{
+		BinaryName: binaryName,
+		GoVersion:  goVersion,
+		Workflows:  workflows,
+		Packages:   packages,
+	}, nil
+}
+
+// writeIfAbsent refuses to clobber a file a user may have already customized.
+func writeIfAbsent(path string, content string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("refusing to overwrite existing file (%s)", path)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("unable to write file (%s): %s", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// Command lib-instance-gen drives github.com/skeletonkey/lib-instance-gen-go/app from a
+// declarative project file instead of a hand-written main.go. See config.go for the
+// ".lib-instance-gen.toml" format.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `lib-instance-gen <command> [arguments]
+
+Commands:
+  init <dir>   scaffold a .lib-instance-gen.toml and init.go in dir
+  generate     run the generator using the project file in the current directory
+  version      print the lib-instance-gen version
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(1)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "init":
+		err = runInit(os.Args[2:])
+	case "generate":
+		err = runGenerate(os.Args[2:])
+	case "version":
+		runVersion()
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "lib-instance-gen: %s\n", err)
+		os.Exit(1)
+	}
+}
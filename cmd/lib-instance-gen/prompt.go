@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// prompt asks label (showing def, if any) on stdin/stdout, re-prompting until validate
+// accepts the answer or the user accepts the default by entering nothing.
+func prompt(r *bufio.Reader, label string, def string, validate func(string) error) (string, error) {
+	for {
+		if def != "" {
+			fmt.Printf("%s [%s]: ", label, def)
+		} else {
+			fmt.Printf("%s: ", label)
+		}
+
+		// ReadString returns both the text typed so far and io.EOF when stdin closes
+		// without a trailing newline; that text is still a valid answer.
+		line, err := r.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("unable to read input: %s", err)
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = def
+		}
+
+		if verr := validate(answer); verr != nil {
+			if errors.Is(err, io.EOF) {
+				return "", fmt.Errorf("unable to read input: %s", err)
+			}
+			fmt.Println(verr)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// promptList is prompt for a comma-separated list, validating each entry.
+func promptList(r *bufio.Reader, label string, def []string, validate func(string) error) ([]string, error) {
+	answer, err := prompt(r, label, strings.Join(def, ","), func(s string) error {
+		for _, entry := range splitList(s) {
+			if err := validate(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return splitList(answer), nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry != "" {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func alwaysValid(string) error { return nil }
+
+func TestPrompt(t *testing.T) {
+	t.Run("accepts typed answer", func(t *testing.T) {
+		r := bufio.NewReader(strings.NewReader("widget\n"))
+		got, err := prompt(r, "Binary name", "", alwaysValid)
+		if err != nil {
+			t.Fatalf("prompt() returned error: %s", err)
+		}
+		if got != "widget" {
+			t.Errorf("prompt() = %q, want %q", got, "widget")
+		}
+	})
+
+	t.Run("blank answer falls back to default", func(t *testing.T) {
+		r := bufio.NewReader(strings.NewReader("\n"))
+		got, err := prompt(r, "Binary name", "fallback", alwaysValid)
+		if err != nil {
+			t.Fatalf("prompt() returned error: %s", err)
+		}
+		if got != "fallback" {
+			t.Errorf("prompt() = %q, want %q", got, "fallback")
+		}
+	})
+
+	t.Run("re-prompts until validate accepts", func(t *testing.T) {
+		r := bufio.NewReader(strings.NewReader("bad\ngood\n"))
+		calls := 0
+		validate := func(s string) error {
+			calls++
+			if s != "good" {
+				return errors.New("not good enough")
+			}
+			return nil
+		}
+
+		got, err := prompt(r, "Answer", "", validate)
+		if err != nil {
+			t.Fatalf("prompt() returned error: %s", err)
+		}
+		if got != "good" {
+			t.Errorf("prompt() = %q, want %q", got, "good")
+		}
+		if calls != 2 {
+			t.Errorf("validate was called %d times, want 2", calls)
+		}
+	})
+
+	t.Run("accepts a valid answer typed before stdin closes without a trailing newline", func(t *testing.T) {
+		r := bufio.NewReader(strings.NewReader("widget"))
+		got, err := prompt(r, "Binary name", "", alwaysValid)
+		if err != nil {
+			t.Fatalf("prompt() returned error: %s", err)
+		}
+		if got != "widget" {
+			t.Errorf("prompt() = %q, want %q", got, "widget")
+		}
+	})
+
+	t.Run("returns an error when stdin closes with no usable answer", func(t *testing.T) {
+		r := bufio.NewReader(strings.NewReader(""))
+		_, err := prompt(r, "Binary name", "", func(string) error {
+			return errors.New("required")
+		})
+		if err == nil {
+			t.Fatal("prompt() = nil error, want one (stdin closed with no valid answer and no default)")
+		}
+	})
+}
+
+func TestPromptList(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("logger, pushover ,rachio\n"))
+	got, err := promptList(r, "Packages", nil, alwaysValid)
+	if err != nil {
+		t.Fatalf("promptList() returned error: %s", err)
+	}
+
+	want := []string{"logger", "pushover", "rachio"}
+	if len(got) != len(want) {
+		t.Fatalf("promptList() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("promptList()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "empty", input: "", want: nil},
+		{name: "single", input: "logger", want: []string{"logger"}},
+		{name: "multiple with spaces", input: "logger, pushover ,rachio", want: []string{"logger", "pushover", "rachio"}},
+		{name: "trailing comma", input: "logger,", want: []string{"logger"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitList(tt.input)
+			if len(got) != len(tt.want) {
+				t.Fatalf("splitList(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("splitList(%q)[%d] = %q, want %q", tt.input, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
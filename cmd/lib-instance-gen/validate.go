@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var (
+	binaryNamePattern  = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+	goVersionPattern   = regexp.MustCompile(`^1\.\d+(\.\d+)?$`)
+	packageNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+)
+
+func validateBinaryName(name string) error {
+	if !binaryNamePattern.MatchString(name) {
+		return fmt.Errorf("binary name (%s) must be a valid Go identifier suffix", name)
+	}
+	return nil
+}
+
+func validateGoVersion(ver string) error {
+	if !goVersionPattern.MatchString(ver) {
+		return fmt.Errorf("go version (%s) must match %s", ver, goVersionPattern.String())
+	}
+	return nil
+}
+
+func validatePackageName(name string) error {
+	if !packageNamePattern.MatchString(name) {
+		return fmt.Errorf("package name (%s) must be a valid Go import path segment", name)
+	}
+	return nil
+}
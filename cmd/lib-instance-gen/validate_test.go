@@ -0,0 +1,72 @@
+package main
+
+import "testing"
+
+func TestValidateBinaryName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "valid", input: "widget", wantErr: false},
+		{name: "valid with dash and underscore", input: "widget-app_2", wantErr: false},
+		{name: "starts with digit", input: "2widget", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+		{name: "contains space", input: "my widget", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBinaryName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateBinaryName(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateGoVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "minor only", input: "1.23", wantErr: false},
+		{name: "minor and patch", input: "1.23.1", wantErr: false},
+		{name: "missing leading 1", input: "2.23", wantErr: true},
+		{name: "non-numeric", input: "1.x", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateGoVersion(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateGoVersion(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePackageName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "valid", input: "logger", wantErr: false},
+		{name: "valid with dash and underscore", input: "my_pkg-2", wantErr: false},
+		{name: "uppercase", input: "Logger", wantErr: true},
+		{name: "starts with digit", input: "2logger", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePackageName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validatePackageName(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package main
+
+import "fmt"
+
+// version is overridden at build time with -ldflags "-X main.version=...".
+var version = "dev"
+
+func runVersion() {
+	fmt.Println("lib-instance-gen " + version)
+}